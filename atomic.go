@@ -0,0 +1,93 @@
+package gogacon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic writes data to path atomically. It writes data into a
+// temp file in the same directory, fsyncs it, renames it over the target, and
+// finally fsyncs the parent directory so the replacement survives a crash
+// or power loss.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp := filepath.Join(dir, fmt.Sprintf("%s.tmp-%d", filepath.Base(path), os.Getpid()))
+
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	dirFile, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer dirFile.Close()
+	return dirFile.Sync()
+}
+
+// rotateBackups shifts path.bak.1..path.bak.(count-1) up by one slot and
+// moves the current file at path into path.bak.1, making room for a new
+// write. It is a no-op if count is zero or path does not yet exist.
+func rotateBackups(path string, count int) error {
+	if count <= 0 {
+		return nil
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	for n := count; n > 1; n-- {
+		src := backupPath(path, n-1)
+		dst := backupPath(path, n)
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+	}
+	return os.Rename(path, backupPath(path, 1))
+}
+
+func backupPath(path string, n int) string {
+	return fmt.Sprintf("%s.bak.%d", path, n)
+}
+
+// RestoreFromBackup replaces the current config file with rotated backup n,
+// where 1 is the most recently rotated backup. Backups only exist when
+// Defaults.BackupCount was set to a positive value at save time.
+func (cm *ConfigManager) RestoreFromBackup(n int) error {
+	if n <= 0 {
+		return NewError("restore config backup", cm.filePath, fmt.Errorf("backup number must be positive, got %d", n))
+	}
+
+	backup := backupPath(cm.filePath, n)
+	data, err := os.ReadFile(backup)
+	if err != nil {
+		return NewError("restore config backup", backup, err)
+	}
+	if err := writeFileAtomic(cm.filePath, data, 0644); err != nil {
+		return NewError("restore config backup", cm.filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package gogacon_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Galdoba/gogacon"
+)
+
+func TestSaveConfig_AtomicWrite(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "default.conf")
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{},
+		AtomicWrite:         true,
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	if err := manager.LoadConfig(configPath, &MockSerializer{}); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := manager.SaveConfig(&MockSerializer{MarshalData: []byte("v1")}); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(content) != "v1" {
+		t.Errorf("unexpected content: %s", content)
+	}
+
+	// no temp files should be left behind
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) != "" && e.Name() != "default.conf" {
+			t.Errorf("unexpected leftover file: %s", e.Name())
+		}
+	}
+}
+
+func TestSaveConfig_BackupRotation(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "default.conf")
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{},
+		AtomicWrite:         true,
+		BackupCount:         2,
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	if err := manager.LoadConfig(configPath, &MockSerializer{}); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	versions := []string{"v1", "v2", "v3"}
+	for _, v := range versions {
+		if err := manager.SaveConfig(&MockSerializer{MarshalData: []byte(v)}); err != nil {
+			t.Fatalf("SaveConfig(%s) failed: %v", v, err)
+		}
+	}
+
+	// current file should hold the latest write
+	content, _ := os.ReadFile(configPath)
+	if string(content) != "v3" {
+		t.Errorf("unexpected current content: %s", content)
+	}
+
+	// bak.1 should hold the previous write, bak.2 the one before that
+	bak1, err := os.ReadFile(configPath + ".bak.1")
+	if err != nil {
+		t.Fatalf("ReadFile bak.1 failed: %v", err)
+	}
+	if string(bak1) != "v2" {
+		t.Errorf("unexpected bak.1 content: %s", bak1)
+	}
+
+	bak2, err := os.ReadFile(configPath + ".bak.2")
+	if err != nil {
+		t.Fatalf("ReadFile bak.2 failed: %v", err)
+	}
+	if string(bak2) != "v1" {
+		t.Errorf("unexpected bak.2 content: %s", bak2)
+	}
+
+	if err := manager.RestoreFromBackup(1); err != nil {
+		t.Fatalf("RestoreFromBackup failed: %v", err)
+	}
+	restored, _ := os.ReadFile(configPath)
+	if string(restored) != "v2" {
+		t.Errorf("unexpected restored content: %s", restored)
+	}
+}
+
+func TestSaveConfig_BackupRotationWithoutAtomicWrite(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "default.conf")
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{},
+		BackupCount:         2,
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	if err := manager.LoadConfig(configPath, &MockSerializer{}); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	versions := []string{"v1", "v2", "v3"}
+	for _, v := range versions {
+		if err := manager.SaveConfig(&MockSerializer{MarshalData: []byte(v)}); err != nil {
+			t.Fatalf("SaveConfig(%s) failed: %v", v, err)
+		}
+	}
+
+	content, _ := os.ReadFile(configPath)
+	if string(content) != "v3" {
+		t.Errorf("unexpected current content: %s", content)
+	}
+
+	bak1, err := os.ReadFile(configPath + ".bak.1")
+	if err != nil {
+		t.Fatalf("ReadFile bak.1 failed: %v", err)
+	}
+	if string(bak1) != "v2" {
+		t.Errorf("unexpected bak.1 content: %s", bak1)
+	}
+
+	bak2, err := os.ReadFile(configPath + ".bak.2")
+	if err != nil {
+		t.Fatalf("ReadFile bak.2 failed: %v", err)
+	}
+	if string(bak2) != "v1" {
+		t.Errorf("unexpected bak.2 content: %s", bak2)
+	}
+}
+
+func TestRestoreFromBackup_MissingBackup(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "default.conf")
+
+	manager, _ := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{},
+		AtomicWrite:         true,
+	})
+	if err := manager.LoadConfig(configPath, &MockSerializer{}); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	err := manager.RestoreFromBackup(1)
+	if err == nil {
+		t.Fatal("expected error restoring missing backup")
+	}
+	if _, ok := err.(gogacon.ConfigError); !ok {
+		t.Errorf("expected ConfigError, got %T", err)
+	}
+}
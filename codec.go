@@ -0,0 +1,159 @@
+package gogacon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Codec converts between a Go value and the serialized byte representation
+// used for one config file format.
+type Codec interface {
+	//Marshal converts v to its serialized byte representation
+	Marshal(v any) ([]byte, error)
+	//Unmarshal parses data into v
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	codecMu sync.RWMutex
+	codecs  = map[string]Codec{
+		".json": jsonCodec{},
+		".yaml": yamlCodec{},
+		".yml":  yamlCodec{},
+		".toml": tomlCodec{},
+	}
+)
+
+// RegisterCodec registers c as the Codec used for files whose extension
+// (including the leading dot, e.g. ".json") matches ext. Registering an
+// extension that already has a codec replaces it; this is how the built-in
+// JSON/YAML/TOML codecs can be swapped out.
+func RegisterCodec(ext string, c Codec) {
+	codecMu.Lock()
+	defer codecMu.Unlock()
+	codecs[ext] = c
+}
+
+// codecFor looks up the Codec registered for path's extension.
+func codecFor(path string) (Codec, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	codecMu.RLock()
+	defer codecMu.RUnlock()
+	c, ok := codecs[ext]
+	if !ok {
+		return nil, fmt.Errorf("no codec registered for extension %q", ext)
+	}
+	return c, nil
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.MarshalIndent(v, "", "  ") }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+type yamlCodec struct{}
+
+func (yamlCodec) Marshal(v any) ([]byte, error)      { return yaml.Marshal(v) }
+func (yamlCodec) Unmarshal(data []byte, v any) error { return yaml.Unmarshal(data, v) }
+
+type tomlCodec struct{}
+
+func (tomlCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (tomlCodec) Unmarshal(data []byte, v any) error {
+	return toml.Unmarshal(data, v)
+}
+
+// LoadInto loads configuration from path (or the manager's default path if
+// path is empty) into v, picking a Codec by file extension via codecFor.
+// Unlike LoadConfig, v does not need to implement Serializer - any struct
+// the chosen codec understands will do.
+func (cm *ConfigManager) LoadInto(path string, v any) error {
+	if path == "" {
+		var err error
+		path, err = cm.resolvePath()
+		if err != nil {
+			return err
+		}
+	}
+	if err := cm.ensureConfigFile(path); err != nil {
+		return err
+	}
+
+	codec, err := codecFor(path)
+	if err != nil {
+		return NewError("resolve codec", path, err)
+	}
+
+	bt, err := os.ReadFile(path)
+	if err != nil {
+		return NewError("read config", path, err)
+	}
+
+	bt, err = cm.applyMigrations(path, bt)
+	if err != nil {
+		return err
+	}
+
+	if err := codec.Unmarshal(bt, v); err != nil {
+		return NewError("unmarshal config", path, err)
+	}
+	if err := cm.decryptSecretFields(v); err != nil {
+		return NewError("decrypt secret fields", path, err)
+	}
+	cm.filePath = path
+	return nil
+}
+
+// SaveFrom saves v to the path the manager was loaded from (via LoadInto or
+// LoadConfig), encoding it with the Codec registered for that path's
+// extension. Fields tagged `gogacon:"...,secret"` are encrypted on a copy
+// of v before marshaling, so v itself is left with plaintext values; if
+// any field was encrypted the file is written with 0600 permissions
+// instead of the usual 0644. v must be a pointer to a struct for secret
+// fields to be encrypted; if it isn't and has any secret-tagged fields,
+// SaveFrom returns an error instead of writing them out as plaintext.
+func (cm *ConfigManager) SaveFrom(v any) error {
+	codec, err := codecFor(cm.filePath)
+	if err != nil {
+		return NewError("resolve codec", cm.filePath, err)
+	}
+
+	toMarshal := v
+	perm := os.FileMode(0644)
+	if clone, ok := cloneStructPtr(v); ok {
+		encrypted, err := cm.encryptSecretFields(clone)
+		if err != nil {
+			return NewError("encrypt secret fields", cm.filePath, err)
+		}
+		toMarshal = clone
+		if encrypted > 0 {
+			perm = 0600
+		}
+	} else if hasSecretFields(v) {
+		return NewError("encrypt secret fields", cm.filePath, fmt.Errorf("SaveFrom requires a pointer to a struct to encrypt secret-tagged fields, got %T", v))
+	}
+
+	bt, err := codec.Marshal(toMarshal)
+	if err != nil {
+		return NewError("marshal config", cm.filePath, err)
+	}
+	if err := cm.writeConfigFileMode(cm.filePath, bt, perm); err != nil {
+		return NewError("save config", cm.filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package gogacon_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Galdoba/gogacon"
+)
+
+type appConfig struct {
+	Name string `json:"name" yaml:"name" toml:"name"`
+	Port int    `json:"port" yaml:"port" toml:"port"`
+}
+
+func newAppConfigManager(t *testing.T) *gogacon.ConfigManager {
+	t.Helper()
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`{"name":"default","port":8080}`)},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+	return manager
+}
+
+func TestLoadInto_JSON(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"name":"svc","port":9090}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	manager := newAppConfigManager(t)
+	var cfg appConfig
+	if err := manager.LoadInto(path, &cfg); err != nil {
+		t.Fatalf("LoadInto failed: %v", err)
+	}
+	if cfg.Name != "svc" || cfg.Port != 9090 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestSaveFrom_JSON(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+
+	manager := newAppConfigManager(t)
+	var cfg appConfig
+	if err := manager.LoadInto(path, &cfg); err != nil {
+		t.Fatalf("LoadInto failed: %v", err)
+	}
+
+	cfg.Name = "svc2"
+	cfg.Port = 1234
+	if err := manager.SaveFrom(&cfg); err != nil {
+		t.Fatalf("SaveFrom failed: %v", err)
+	}
+
+	var roundTripped appConfig
+	if err := manager.LoadInto(path, &roundTripped); err != nil {
+		t.Fatalf("LoadInto (round trip) failed: %v", err)
+	}
+	if roundTripped != cfg {
+		t.Errorf("round trip mismatch: got %+v, want %+v", roundTripped, cfg)
+	}
+}
+
+func TestLoadInto_UnknownExtension(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.ini")
+
+	manager := newAppConfigManager(t)
+	var cfg appConfig
+	err := manager.LoadInto(path, &cfg)
+	if err == nil {
+		t.Fatal("expected error for unregistered extension")
+	}
+	if _, ok := err.(gogacon.ConfigError); !ok {
+		t.Errorf("expected ConfigError, got %T", err)
+	}
+}
+
+func TestRegisterCodec_NewExtension(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.custom")
+	if err := os.WriteFile(path, []byte("name=svc;port=42"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	gogacon.RegisterCodec(".custom", customCodec{})
+
+	manager := newAppConfigManager(t)
+	var cfg appConfig
+	if err := manager.LoadInto(path, &cfg); err != nil {
+		t.Fatalf("LoadInto failed: %v", err)
+	}
+	if cfg.Name != "svc" || cfg.Port != 42 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+// customCodec is a minimal stand-in used to verify that a codec registered
+// via RegisterCodec for a new extension is used by LoadInto.
+type customCodec struct{}
+
+func (customCodec) Marshal(v any) ([]byte, error) {
+	cfg := v.(*appConfig)
+	return []byte("name=" + cfg.Name + ";port=42"), nil
+}
+
+func (customCodec) Unmarshal(data []byte, v any) error {
+	cfg := v.(*appConfig)
+	cfg.Name = "svc"
+	cfg.Port = 42
+	return nil
+}
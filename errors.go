@@ -12,9 +12,9 @@ type ConfigError struct {
 // Error - formats the error message with contextual information.
 func (e ConfigError) Error() string {
 	if e.Path != "" {
-		return fmt.Sprintf("config error: %s: %s", e.Operation, e.Err)
+		return fmt.Sprintf("config error: %s %q: %v", e.Operation, e.Path, e.Err)
 	}
-	return fmt.Sprintf("config error: %s %q: %v", e.Operation, e.Path, e.Err)
+	return fmt.Sprintf("config error: %s: %s", e.Operation, e.Err)
 }
 
 func (e ConfigError) Unwrap() error { return e.Err }
@@ -0,0 +1,159 @@
+package gogacon
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// LayerOptions configures the env-var and flag override layers applied on
+// top of a file-loaded config by ConfigManager.LoadLayered.
+type LayerOptions struct {
+	Path      string        //Config file path (optional, resolved like LoadInto when empty)
+	EnvPrefix string        //Prefix applied before a field's derived env var name (e.g. "MYAPP")
+	Flags     *flag.FlagSet //Optional flag set consulted for explicit overrides
+}
+
+// fieldTag is the parsed form of a `gogacon:"key[,secret][,env=NAME]"`
+// struct tag.
+type fieldTag struct {
+	key    string
+	env    string
+	secret bool
+}
+
+func parseFieldTag(tag string) (fieldTag, bool) {
+	if tag == "" {
+		return fieldTag{}, false
+	}
+	parts := strings.Split(tag, ",")
+	ft := fieldTag{key: parts[0]}
+	for _, p := range parts[1:] {
+		switch {
+		case p == "secret":
+			ft.secret = true
+		case strings.HasPrefix(p, "env="):
+			ft.env = strings.TrimPrefix(p, "env=")
+		}
+	}
+	return ft, ft.key != ""
+}
+
+// envName returns the environment variable name for a tagged field: an
+// explicit env=NAME override if present (the prefix is not applied to it),
+// otherwise the field's dotted key, upper-cased with '.' replaced by '_',
+// joined to a non-empty prefix with '_'.
+func envName(prefix string, ft fieldTag) string {
+	if ft.env != "" {
+		return ft.env
+	}
+	name := strings.ToUpper(strings.ReplaceAll(ft.key, ".", "_"))
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+// LoadLayered loads target the same way LoadInto does (the file on disk,
+// which is created from the built-in defaults if it does not exist yet;
+// keys missing from an existing file are not filled in from the defaults)
+// and then overlays, in order, environment variables and - if opts.Flags
+// is set - flags explicitly set on it, so later layers win. Overrides are matched to struct fields via `gogacon:"key"`
+// tags using reflection; target must be a pointer to a struct. A tagged
+// field may sit directly on target or inside any number of untagged
+// nested structs (e.g. a `Server ServerConfig` grouping field), so a key
+// like "server.port" can describe either layout.
+func (cm *ConfigManager) LoadLayered(target any, opts LayerOptions) error {
+	if err := cm.LoadInto(opts.Path, target); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return NewError("load layered config", cm.filePath, fmt.Errorf("target must be a pointer to a struct"))
+	}
+
+	setFlags := map[string]*flag.Flag{}
+	if opts.Flags != nil {
+		opts.Flags.Visit(func(f *flag.Flag) { setFlags[f.Name] = f })
+	}
+
+	return walkTaggedFields(v.Elem(), func(ft fieldTag, field reflect.Value) error {
+		if raw, present := os.LookupEnv(envName(opts.EnvPrefix, ft)); present {
+			if err := setFieldFromString(field, raw); err != nil {
+				return NewError("apply env override", ft.key, err)
+			}
+		}
+
+		if f, ok := setFlags[ft.key]; ok {
+			if err := setFieldFromString(field, f.Value.String()); err != nil {
+				return NewError("apply flag override", ft.key, err)
+			}
+		}
+		return nil
+	})
+}
+
+// walkTaggedFields calls fn for every `gogacon:"..."`-tagged field
+// reachable from v (a struct value), recursing into untagged nested
+// struct fields so a dotted key like "server.port" can name either a flat
+// field or one nested inside an untagged grouping struct.
+func walkTaggedFields(v reflect.Value, fn func(ft fieldTag, field reflect.Value) error) error {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		ft, ok := parseFieldTag(field.Tag.Get("gogacon"))
+		if ok {
+			if err := fn(ft, v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if fv := v.Field(i); fv.Kind() == reflect.Struct {
+			if err := walkTaggedFields(fv, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setFieldFromString assigns raw to field after converting it to field's
+// kind. Supported kinds cover what config values typically need: strings,
+// signed/unsigned integers, floats, and bools.
+func setFieldFromString(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field kind %s", field.Kind())
+	}
+	return nil
+}
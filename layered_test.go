@@ -0,0 +1,143 @@
+package gogacon_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Galdoba/gogacon"
+)
+
+type serverConfig struct {
+	Host string `json:"host" gogacon:"server.host"`
+	Port int    `json:"port" gogacon:"server.port,env=SERVER_PORT"`
+}
+
+func TestLoadLayered_EnvOverride(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"host":"localhost","port":8080}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	t.Setenv("SERVER_PORT", "9090")
+	t.Setenv("MYAPP_SERVER_HOST", "0.0.0.0")
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`{"host":"","port":0}`)},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	var cfg serverConfig
+	err = manager.LoadLayered(&cfg, gogacon.LayerOptions{Path: path, EnvPrefix: "MYAPP"})
+	if err != nil {
+		t.Fatalf("LoadLayered failed: %v", err)
+	}
+
+	if cfg.Host != "0.0.0.0" {
+		t.Errorf("expected host overridden by MYAPP_SERVER_HOST, got %q", cfg.Host)
+	}
+	if cfg.Port != 9090 {
+		t.Errorf("expected port overridden by explicit env=SERVER_PORT, got %d", cfg.Port)
+	}
+}
+
+func TestLoadLayered_FlagOverride(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"host":"localhost","port":8080}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("server.host", "localhost", "")
+	if err := fs.Parse([]string{"-server.host=override.example"}); err != nil {
+		t.Fatalf("flag parse failed: %v", err)
+	}
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`{"host":"","port":0}`)},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	var cfg serverConfig
+	err = manager.LoadLayered(&cfg, gogacon.LayerOptions{Path: path, Flags: fs})
+	if err != nil {
+		t.Fatalf("LoadLayered failed: %v", err)
+	}
+
+	if cfg.Host != "override.example" {
+		t.Errorf("expected host overridden by flag, got %q", cfg.Host)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("expected port untouched by flags, got %d", cfg.Port)
+	}
+}
+
+type nestedAppConfig struct {
+	Server struct {
+		Host string `json:"host" gogacon:"server.host"`
+		Port int    `json:"port" gogacon:"server.port,env=SERVER_PORT"`
+	} `json:"server"`
+}
+
+func TestLoadLayered_NestedStructEnvOverride(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"server":{"host":"localhost","port":8080}}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	t.Setenv("SERVER_PORT", "9090")
+	t.Setenv("MYAPP_SERVER_HOST", "0.0.0.0")
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`{"server":{"host":"","port":0}}`)},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	var cfg nestedAppConfig
+	err = manager.LoadLayered(&cfg, gogacon.LayerOptions{Path: path, EnvPrefix: "MYAPP"})
+	if err != nil {
+		t.Fatalf("LoadLayered failed: %v", err)
+	}
+
+	if cfg.Server.Host != "0.0.0.0" {
+		t.Errorf("expected nested host overridden by MYAPP_SERVER_HOST, got %q", cfg.Server.Host)
+	}
+	if cfg.Server.Port != 9090 {
+		t.Errorf("expected nested port overridden by explicit env=SERVER_PORT, got %d", cfg.Server.Port)
+	}
+}
+
+func TestLoadLayered_RequiresStructPointer(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+	if err := os.WriteFile(path, []byte(`"hello"`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	manager, _ := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`""`)},
+	})
+
+	var notAStruct string
+	err := manager.LoadLayered(&notAStruct, gogacon.LayerOptions{Path: path})
+	if err == nil {
+		t.Fatal("expected error for non-struct target")
+	}
+	if _, ok := err.(gogacon.ConfigError); !ok {
+		t.Errorf("expected ConfigError, got %T", err)
+	}
+}
@@ -8,14 +8,19 @@ import (
 
 // Defaults contains default data for ConfigManager
 type Defaults struct {
-	AppName             string     //Application Name (required)
-	DefaultConfigValues Serializer //Default configuration values (required)
+	AppName             string       //Application Name (required)
+	DefaultConfigValues Serializer   //Default configuration values (required)
+	AtomicWrite         bool         //Write config via temp file + rename + fsync instead of os.WriteFile
+	BackupCount         int          //Number of rotated backups to keep before each write, atomic or not (0 disables rotation)
+	PathResolver        PathResolver //Resolves the default config path (optional, defaults to XDG-based resolution)
+	Migrations          []Migration  //Schema migrations applied, in order, on load (optional)
 }
 
 // ConfigManager manages loading and savings of configuration
 type ConfigManager struct {
-	defaults Defaults
-	filePath string
+	defaults    Defaults
+	filePath    string
+	keyProvider KeyProvider
 }
 
 // NewConfigManager creates new ConfigManager instance
@@ -30,16 +35,6 @@ func NewConfigManager(d Defaults) (*ConfigManager, error) {
 	return &ConfigManager{defaults: d}, nil
 }
 
-// buildPath creates default filepath to configuration file
-// Returns absolute path or error
-func (cm *ConfigManager) buildPath() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(home, ".config", cm.defaults.AppName, "default.conf"), nil
-}
-
 func (cm *ConfigManager) ensureConfigFile(path string) error {
 	if _, err := os.Stat(path); err == nil {
 		return nil
@@ -54,17 +49,47 @@ func (cm *ConfigManager) ensureConfigFile(path string) error {
 	if err != nil {
 		return NewError("marshal default config", path, err)
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := cm.writeConfigFile(path, data); err != nil {
 		return NewError("create default config", path, err)
 	}
 	return nil
 }
 
+// writeConfigFile writes data to path with the default 0644 permissions.
+func (cm *ConfigManager) writeConfigFile(path string, data []byte) error {
+	return cm.writeConfigFileMode(path, data, 0644)
+}
+
+// writeConfigFileMode writes data to path with perm, honoring
+// Defaults.AtomicWrite and Defaults.BackupCount. BackupCount rotation
+// applies regardless of AtomicWrite; with AtomicWrite unset the write
+// itself behaves like a plain os.WriteFile, matching the manager's
+// historical behavior.
+func (cm *ConfigManager) writeConfigFileMode(path string, data []byte, perm os.FileMode) error {
+	if err := rotateBackups(path, cm.defaults.BackupCount); err != nil {
+		return err
+	}
+	if !cm.defaults.AtomicWrite {
+		// os.WriteFile only applies perm when creating a new file, so an
+		// existing file's mode must be chmod'd explicitly to pick up a
+		// tightened permission (e.g. 0600 for secret fields).
+		if err := os.WriteFile(path, data, perm); err != nil {
+			return err
+		}
+		return os.Chmod(path, perm)
+	}
+	return writeFileAtomic(path, data, perm)
+}
+
 // LoadConfig loads configuration from path provided or from default path
 // Creates configuration file with default values at default filepath
 func (cm *ConfigManager) LoadConfig(filePath string, target Serializer) error {
 	if filePath == "" {
-		return fmt.Errorf("no path provided")
+		var err error
+		filePath, err = cm.resolvePath()
+		if err != nil {
+			return err
+		}
 	}
 	if err := cm.ensureConfigFile(filePath); err != nil {
 		return err
@@ -75,6 +100,11 @@ func (cm *ConfigManager) LoadConfig(filePath string, target Serializer) error {
 		return NewError("read config", filePath, err)
 	}
 
+	bt, err = cm.applyMigrations(filePath, bt)
+	if err != nil {
+		return err
+	}
+
 	if err = target.Unmarshal(bt); err != nil {
 		return NewError("unmarshal config", filePath, err)
 	}
@@ -82,13 +112,40 @@ func (cm *ConfigManager) LoadConfig(filePath string, target Serializer) error {
 	return nil
 }
 
+// applyMigrations runs Defaults.Migrations over bt (read from path),
+// persisting the upgraded file atomically if any migration applied. The
+// file's existing permissions (e.g. 0600 for a config with encrypted
+// secret fields) are preserved rather than reset to the 0644 default.
+func (cm *ConfigManager) applyMigrations(path string, bt []byte) ([]byte, error) {
+	if len(cm.defaults.Migrations) == 0 {
+		return bt, nil
+	}
+
+	upgraded, err := migrate(bt, filepath.Dir(path), cm.defaults.Migrations)
+	if err != nil {
+		return nil, NewError("migrate config", path, err)
+	}
+	if string(upgraded) == string(bt) {
+		return bt, nil
+	}
+
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := writeFileAtomic(path, upgraded, perm); err != nil {
+		return nil, NewError("persist migrated config", path, err)
+	}
+	return upgraded, nil
+}
+
 // SaveConfig saves configuration to path it was loaded from
 func (cm *ConfigManager) SaveConfig(config Serializer) error {
 	bt, err := config.Marshal()
 	if err != nil {
 		return NewError("marshal config", "", err)
 	}
-	if err := os.WriteFile(cm.filePath, bt, 0644); err != nil {
+	if err := cm.writeConfigFile(cm.filePath, bt); err != nil {
 		return NewError("save config", cm.filePath, err)
 	}
 	return nil
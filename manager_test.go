@@ -27,13 +27,19 @@ func (m *MockSerializer) Unmarshal(data []byte) error {
 	return m.UnmarshalErr
 }
 
+// fixedResolver is a gogacon.PathResolver that always points at path,
+// letting tests pin the config location without depending on $HOME or the
+// host OS's XDG/AppData conventions.
+type fixedResolver struct {
+	path string
+}
+
+func (r fixedResolver) ConfigFilePath(appName string) (string, error) { return r.path, nil }
+func (r fixedResolver) SearchPaths(appName string) []string           { return nil }
+
 func TestConfigManager_FirstRun(t *testing.T) {
-	if runtime.GOOS != "linux" {
-		t.SkipNow()
-	}
-	// Создаем временную директорию
 	tempDir := t.TempDir()
-	t.Setenv("HOME", tempDir)
+	configPath := filepath.Join(tempDir, ".config", "testapp", "default.conf")
 
 	// Мок сериализатора с дефолтными значениями
 	mockSerializer := &MockSerializer{
@@ -43,6 +49,7 @@ func TestConfigManager_FirstRun(t *testing.T) {
 	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
 		AppName:             "testapp",
 		DefaultConfigValues: mockSerializer,
+		PathResolver:        fixedResolver{path: configPath},
 	})
 	if err != nil {
 		t.Fatalf("NewConfigManager failed: %v", err)
@@ -55,7 +62,6 @@ func TestConfigManager_FirstRun(t *testing.T) {
 	}
 
 	// Проверяем что файл создан
-	configPath := filepath.Join(tempDir, ".config", "testapp", "default.conf")
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		t.Fatal("Config file not created")
 	}
@@ -68,11 +74,7 @@ func TestConfigManager_FirstRun(t *testing.T) {
 }
 
 func TestLoadConfig_ExistingConfig(t *testing.T) {
-	if runtime.GOOS != "linux" {
-		t.SkipNow()
-	}
 	tempDir := t.TempDir()
-	t.Setenv("HOME", tempDir)
 
 	// Создаем предварительно заполненный конфиг
 	configPath := filepath.Join(tempDir, ".config", "testapp", "default.conf")
@@ -82,6 +84,7 @@ func TestLoadConfig_ExistingConfig(t *testing.T) {
 	manager, _ := gogacon.NewConfigManager(gogacon.Defaults{
 		AppName:             "testapp",
 		DefaultConfigValues: &MockSerializer{},
+		PathResolver:        fixedResolver{path: configPath},
 	})
 
 	target := &MockSerializer{}
@@ -96,11 +99,7 @@ func TestLoadConfig_ExistingConfig(t *testing.T) {
 }
 
 func TestLoadConfig_InvalidConfig(t *testing.T) {
-	if runtime.GOOS != "linux" {
-		t.SkipNow()
-	}
 	tempDir := t.TempDir()
-	t.Setenv("HOME", tempDir)
 
 	// Создаем поврежденный конфиг
 	configPath := filepath.Join(tempDir, ".config", "testapp", "default.conf")
@@ -112,6 +111,7 @@ func TestLoadConfig_InvalidConfig(t *testing.T) {
 		DefaultConfigValues: &MockSerializer{
 			MarshalData: []byte("default data"),
 		},
+		PathResolver: fixedResolver{path: configPath},
 	})
 
 	target := &MockSerializer{
@@ -140,19 +140,33 @@ func TestLoadConfig_PermissionDenied(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Skipping permission test on Windows")
 	}
+	if os.Geteuid() == 0 {
+		t.Skip("Skipping permission test: running as root, which ignores file mode bits")
+	}
 
 	tempDir := t.TempDir()
-	t.Setenv("HOME", tempDir)
 
-	// Создаем директорию без прав на запись
-	configDir := filepath.Join(tempDir, ".config", "testapp")
-	os.MkdirAll(configDir, 0555) // Только чтение
+	// Делаем родительскую директорию доступной только для чтения, не
+	// создавая саму configDir - иначе MkdirAll(configDir) становится
+	// no-op'ом и ошибка вместо этого всплывает из writeConfigFile.
+	parentDir := filepath.Join(tempDir, ".config")
+	if err := os.MkdirAll(parentDir, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.Chmod(parentDir, 0555); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	defer os.Chmod(parentDir, 0755) // let t.TempDir() clean up afterward
+
+	configDir := filepath.Join(parentDir, "testapp")
+	configPath := filepath.Join(configDir, "default.conf")
 
 	manager, _ := gogacon.NewConfigManager(gogacon.Defaults{
 		AppName: "testapp",
 		DefaultConfigValues: &MockSerializer{
 			MarshalData: []byte("test data"),
 		},
+		PathResolver: fixedResolver{path: configPath},
 	})
 
 	err := manager.LoadConfig("", &MockSerializer{})
@@ -168,9 +182,6 @@ func TestLoadConfig_PermissionDenied(t *testing.T) {
 }
 
 func TestLoadConfig_SpecificPath(t *testing.T) {
-	if runtime.GOOS != "linux" {
-		t.SkipNow()
-	}
 	tempDir := t.TempDir()
 	customPath := filepath.Join(tempDir, "custom.conf")
 	os.WriteFile(customPath, []byte("custom config"), 0644)
@@ -192,9 +203,6 @@ func TestLoadConfig_SpecificPath(t *testing.T) {
 }
 
 func TestNewConfigManager_Validation(t *testing.T) {
-	if runtime.GOOS != "linux" {
-		t.SkipNow()
-	}
 	tests := []struct {
 		name     string
 		defaults gogacon.Defaults
@@ -215,7 +223,8 @@ func TestNewConfigManager_Validation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			_, err := gogacon.NewConfigManager(tt.defaults)
-			if err == nil || err.Error() != tt.errMsg {
+			cfgErr, ok := err.(gogacon.ConfigError)
+			if !ok || cfgErr.Err.Error() != tt.errMsg {
 				t.Errorf("Expected error %q, got %v", tt.errMsg, err)
 			}
 		})
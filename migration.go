@@ -0,0 +1,91 @@
+package gogacon
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Migration upgrades a raw config file from FromVersion to ToVersion.
+// Migrate receives the file's raw bytes (in whatever format it is stored
+// in) and returns the upgraded bytes in that same format.
+type Migration struct {
+	FromVersion int
+	ToVersion   int
+	Migrate     func(raw []byte) ([]byte, error)
+	// Cleanup, if set, runs after Migrate succeeds and can delete or
+	// rename sidecar files made obsolete by this migration (e.g. an old
+	// secrets file folded into the main config). dir is the config
+	// file's directory.
+	Cleanup func(dir string) error
+}
+
+// schemaVersionProbe is the minimal shape gogacon needs to read a config
+// file's top-level schema_version field, independent of its format.
+type schemaVersionProbe struct {
+	SchemaVersion int `json:"schema_version" yaml:"schema_version" toml:"schema_version"`
+}
+
+// readSchemaVersion parses just the schema_version field out of raw,
+// trying JSON, then YAML, then TOML in turn. A file with no
+// schema_version field at all is treated as version 0.
+func readSchemaVersion(raw []byte) (int, error) {
+	var probe schemaVersionProbe
+	if err := json.Unmarshal(raw, &probe); err == nil {
+		return probe.SchemaVersion, nil
+	}
+	if err := yaml.Unmarshal(raw, &probe); err == nil {
+		return probe.SchemaVersion, nil
+	}
+	if err := toml.Unmarshal(raw, &probe); err == nil {
+		return probe.SchemaVersion, nil
+	}
+	return 0, fmt.Errorf("could not determine schema_version: unrecognized config format")
+}
+
+// migrate walks migrations from raw's current schema_version, applying
+// whichever migration's FromVersion matches, until none does. It returns
+// the (possibly unchanged) upgraded bytes. On failure it returns the
+// original raw bytes unchanged alongside the error, so a bad migration
+// never leaves a half-upgraded file on disk.
+func migrate(raw []byte, dir string, migrations []Migration) ([]byte, error) {
+	if len(migrations) == 0 {
+		return raw, nil
+	}
+
+	version, err := readSchemaVersion(raw)
+	if err != nil {
+		return raw, err
+	}
+
+	current := raw
+	for {
+		next := migrationFrom(migrations, version)
+		if next == nil {
+			return current, nil
+		}
+
+		upgraded, err := next.Migrate(current)
+		if err != nil {
+			return raw, fmt.Errorf("migrate schema %d -> %d: %w", next.FromVersion, next.ToVersion, err)
+		}
+		if next.Cleanup != nil {
+			if err := next.Cleanup(dir); err != nil {
+				return raw, fmt.Errorf("migrate schema %d -> %d: cleanup: %w", next.FromVersion, next.ToVersion, err)
+			}
+		}
+		current = upgraded
+		version = next.ToVersion
+	}
+}
+
+func migrationFrom(migrations []Migration, version int) *Migration {
+	for i := range migrations {
+		if migrations[i].FromVersion == version {
+			return &migrations[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package gogacon_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Galdoba/gogacon"
+)
+
+// addV1ToV2 simulates a real-world migration: renaming a field.
+func addV1ToV2(raw []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	if name, ok := doc["username"]; ok {
+		doc["user_name"] = name
+		delete(doc, "username")
+	}
+	doc["schema_version"] = 2
+	return json.Marshal(doc)
+}
+
+// addV2ToV3 simulates a second migration in the same chain.
+func addV2ToV3(raw []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	doc["schema_version"] = 3
+	doc["greeting"] = "hello"
+	return json.Marshal(doc)
+}
+
+func TestLoadConfig_MigrationChain(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "default.conf")
+	if err := os.WriteFile(configPath, []byte(`{"schema_version":1,"username":"alice"}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{},
+		Migrations: []gogacon.Migration{
+			{FromVersion: 1, ToVersion: 2, Migrate: addV1ToV2},
+			{FromVersion: 2, ToVersion: 3, Migrate: addV2ToV3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	target := &MockSerializer{}
+	if err := manager.LoadConfig(configPath, target); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	persisted, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(persisted, &doc); err != nil {
+		t.Fatalf("persisted file is not valid JSON: %v", err)
+	}
+	if doc["schema_version"] != 3.0 {
+		t.Errorf("expected schema_version 3, got %v", doc["schema_version"])
+	}
+	if doc["user_name"] != "alice" {
+		t.Errorf("expected user_name to carry over from username, got %v", doc["user_name"])
+	}
+	if doc["greeting"] != "hello" {
+		t.Errorf("expected greeting added by second migration, got %v", doc["greeting"])
+	}
+	if target.UnmarshalCalls != 1 {
+		t.Error("expected Unmarshal to be called with the migrated content")
+	}
+}
+
+func TestLoadConfig_MigrationPreservesExistingFileMode(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "default.conf")
+	if err := os.WriteFile(configPath, []byte(`{"schema_version":1,"username":"alice"}`), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{},
+		Migrations: []gogacon.Migration{
+			{FromVersion: 1, ToVersion: 2, Migrate: addV1ToV2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	if err := manager.LoadConfig(configPath, &MockSerializer{}); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected migration persist to preserve 0600 mode, got %v", info.Mode().Perm())
+	}
+}
+
+func TestLoadConfig_MigrationFailureKeepsOriginalFile(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "default.conf")
+	original := []byte(`{"schema_version":1,"username":"alice"}`)
+	if err := os.WriteFile(configPath, original, 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	failingMigration := gogacon.Migration{
+		FromVersion: 1,
+		ToVersion:   2,
+		Migrate: func(raw []byte) ([]byte, error) {
+			return nil, errors.New("boom")
+		},
+	}
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{},
+		Migrations:          []gogacon.Migration{failingMigration},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	err = manager.LoadConfig(configPath, &MockSerializer{})
+	if err == nil {
+		t.Fatal("expected migration failure to surface as an error")
+	}
+	if _, ok := err.(gogacon.ConfigError); !ok {
+		t.Errorf("expected ConfigError, got %T", err)
+	}
+
+	onDisk, readErr := os.ReadFile(configPath)
+	if readErr != nil {
+		t.Fatalf("ReadFile failed: %v", readErr)
+	}
+	if !bytes.Equal(onDisk, original) {
+		t.Errorf("expected original file to be left untouched on migration failure, got %s", onDisk)
+	}
+}
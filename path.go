@@ -0,0 +1,113 @@
+package gogacon
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// PathResolver determines where a ConfigManager's config file should live
+// when no explicit path is given, and which additional locations to check
+// for an already-existing file. Defaults.PathResolver lets callers override
+// the built-in XDG-based resolution, e.g. to pin a path in tests.
+type PathResolver interface {
+	// ConfigFilePath returns the path a new config file should be created
+	// at for appName.
+	ConfigFilePath(appName string) (string, error)
+	// SearchPaths returns additional locations, in priority order, to
+	// check for an existing config file when none exists at
+	// ConfigFilePath's location.
+	SearchPaths(appName string) []string
+}
+
+// xdgResolver is the default PathResolver. On Unix it follows the XDG Base
+// Directory Specification, honoring $XDG_CONFIG_HOME and searching
+// $XDG_CONFIG_DIRS on read. On Windows and macOS it defers to
+// os.UserConfigDir, which already implements the platform convention
+// (%AppData% / ~/Library/Application Support).
+type xdgResolver struct{}
+
+const configFileName = "default.conf"
+
+func (xdgResolver) ConfigFilePath(appName string) (string, error) {
+	dir, err := xdgConfigHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, appName, configFileName), nil
+}
+
+func (xdgResolver) SearchPaths(appName string) []string {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		return nil
+	}
+	var paths []string
+	for _, dir := range xdgConfigDirs() {
+		paths = append(paths, filepath.Join(dir, appName, configFileName))
+	}
+	return paths
+}
+
+// xdgConfigHome returns the single directory new config files are written
+// under: os.UserConfigDir on Windows/macOS, or $XDG_CONFIG_HOME (falling
+// back to $HOME/.config) on other platforms.
+func xdgConfigHome() (string, error) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		return os.UserConfigDir()
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config"), nil
+}
+
+// xdgConfigDirs returns the colon-separated $XDG_CONFIG_DIRS list (defaulting
+// to /etc/xdg per spec) used as fallback read locations on Unix.
+func xdgConfigDirs() []string {
+	dirs := os.Getenv("XDG_CONFIG_DIRS")
+	if dirs == "" {
+		dirs = "/etc/xdg"
+	}
+	var result []string
+	for _, d := range strings.Split(dirs, string(os.PathListSeparator)) {
+		if d != "" {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// resolver returns the configured PathResolver, falling back to the
+// built-in XDG-based one.
+func (cm *ConfigManager) resolver() PathResolver {
+	if cm.defaults.PathResolver != nil {
+		return cm.defaults.PathResolver
+	}
+	return xdgResolver{}
+}
+
+// resolvePath returns the path to use when none was explicitly given:
+// resolver().ConfigFilePath if a file already exists there, else the first
+// of resolver().SearchPaths that already exists on disk, else
+// resolver().ConfigFilePath.
+func (cm *ConfigManager) resolvePath() (string, error) {
+	r := cm.resolver()
+	primary, err := r.ConfigFilePath(cm.defaults.AppName)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(primary); err == nil {
+		return primary, nil
+	}
+	for _, p := range r.SearchPaths(cm.defaults.AppName) {
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return primary, nil
+}
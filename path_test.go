@@ -0,0 +1,93 @@
+package gogacon_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Galdoba/gogacon"
+)
+
+func TestLoadConfig_XDGConfigHome(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("XDG_CONFIG_HOME only applies on other Unix-likes")
+	}
+
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	manager, _ := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte("xdg config")},
+	})
+
+	if err := manager.LoadConfig("", &MockSerializer{}); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	configPath := filepath.Join(tempDir, "testapp", "default.conf")
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("config not written under XDG_CONFIG_HOME: %v", err)
+	}
+	if string(content) != "xdg config" {
+		t.Errorf("unexpected content: %s", content)
+	}
+}
+
+func TestLoadConfig_XDGConfigDirsFallback(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("XDG_CONFIG_DIRS only applies on other Unix-likes")
+	}
+
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+
+	sysDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_DIRS", sysDir)
+
+	existing := filepath.Join(sysDir, "testapp", "default.conf")
+	if err := os.MkdirAll(filepath.Dir(existing), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(existing, []byte("system config"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	manager, _ := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte("default config")},
+	})
+
+	target := &MockSerializer{}
+	if err := manager.LoadConfig("", target); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if target.UnmarshalCalls != 1 {
+		t.Error("expected the XDG_CONFIG_DIRS file to be loaded rather than creating a new one")
+	}
+}
+
+func TestLoadConfig_CustomPathResolver(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "wherever", "myapp.conf")
+
+	manager, _ := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte("resolver config")},
+		PathResolver:        fixedResolver{path: configPath},
+	})
+
+	if err := manager.LoadConfig("", &MockSerializer{}); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("config not written via custom PathResolver: %v", err)
+	}
+	if string(content) != "resolver config" {
+		t.Errorf("unexpected content: %s", content)
+	}
+}
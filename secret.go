@@ -0,0 +1,229 @@
+package gogacon
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	keyring "github.com/zalando/go-keyring"
+)
+
+// KeyProvider supplies the symmetric key used to encrypt and decrypt
+// fields tagged `gogacon:"...,secret"`. Implementations might read an env
+// var, a file, or an OS keychain.
+type KeyProvider interface {
+	//Key returns the raw AES key (16, 24, or 32 bytes for AES-128/192/256)
+	Key() ([]byte, error)
+}
+
+// EnvKeyProvider reads the key as base64 from an environment variable.
+type EnvKeyProvider struct {
+	Var string
+}
+
+func (p EnvKeyProvider) Key() ([]byte, error) {
+	raw := os.Getenv(p.Var)
+	if raw == "" {
+		return nil, fmt.Errorf("env var %q is not set", p.Var)
+	}
+	return base64.StdEncoding.DecodeString(raw)
+}
+
+// FileKeyProvider reads the key as base64 text from a file.
+type FileKeyProvider struct {
+	Path string
+}
+
+func (p FileKeyProvider) Key() ([]byte, error) {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+}
+
+// KeyringKeyProvider reads the key as base64 from the OS keychain (Keychain
+// on macOS, Credential Manager on Windows, Secret Service/D-Bus on Linux)
+// via github.com/zalando/go-keyring.
+type KeyringKeyProvider struct {
+	Service string
+	User    string
+}
+
+func (p KeyringKeyProvider) Key() ([]byte, error) {
+	raw, err := keyring.Get(p.Service, p.User)
+	if err != nil {
+		return nil, fmt.Errorf("read key from keyring: %w", err)
+	}
+	return base64.StdEncoding.DecodeString(raw)
+}
+
+// secretPrefix marks a field value as AES-GCM ciphertext rather than
+// plaintext, so a config file mixing secret and non-secret fields stays
+// valid JSON/YAML/TOML.
+const secretPrefix = "enc:v1:"
+
+// WithKeyProvider sets the KeyProvider used to encrypt and decrypt fields
+// tagged `gogacon:"...,secret"` by LoadInto/SaveFrom, returning cm so it
+// can be chained off NewConfigManager.
+func (cm *ConfigManager) WithKeyProvider(kp KeyProvider) *ConfigManager {
+	cm.keyProvider = kp
+	return cm
+}
+
+func (cm *ConfigManager) gcm() (cipher.AEAD, error) {
+	if cm.keyProvider == nil {
+		return nil, fmt.Errorf("no KeyProvider configured; call WithKeyProvider")
+	}
+	key, err := cm.keyProvider.Key()
+	if err != nil {
+		return nil, fmt.Errorf("load key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (cm *ConfigManager) encryptSecret(plaintext string) (string, error) {
+	gcm, err := cm.gcm()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	ciphertext := gcm.Seal(nil, nonce, []byte(plaintext), nil)
+	return secretPrefix + base64.StdEncoding.EncodeToString(nonce) + ":" + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func (cm *ConfigManager) decryptSecret(enc string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(enc, secretPrefix), ":")
+	if len(parts) != 2 {
+		return "", fmt.Errorf("malformed secret value")
+	}
+	nonce, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cm.gcm()
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// encryptSecretFields walks v's `gogacon:"...,secret"`-tagged string
+// fields (including ones nested inside untagged grouping structs, see
+// walkTaggedFields) and encrypts each in place, skipping any
+// already-encrypted value. It is a no-op if v is not a pointer to a
+// struct. It returns how many fields it encrypted.
+func (cm *ConfigManager) encryptSecretFields(v any) (int, error) {
+	elem, ok := structElem(v)
+	if !ok {
+		return 0, nil
+	}
+
+	encrypted := 0
+	err := walkTaggedFields(elem, func(ft fieldTag, fv reflect.Value) error {
+		if !ft.secret {
+			return nil
+		}
+		if fv.Kind() != reflect.String {
+			return fmt.Errorf("secret field %q must be a string", ft.key)
+		}
+		if strings.HasPrefix(fv.String(), secretPrefix) {
+			return nil
+		}
+		enc, err := cm.encryptSecret(fv.String())
+		if err != nil {
+			return fmt.Errorf("encrypt field %q: %w", ft.key, err)
+		}
+		fv.SetString(enc)
+		encrypted++
+		return nil
+	})
+	return encrypted, err
+}
+
+// decryptSecretFields is encryptSecretFields's inverse, run after a load.
+// It is a no-op if v is not a pointer to a struct.
+func (cm *ConfigManager) decryptSecretFields(v any) error {
+	elem, ok := structElem(v)
+	if !ok {
+		return nil
+	}
+
+	return walkTaggedFields(elem, func(ft fieldTag, fv reflect.Value) error {
+		if !ft.secret || fv.Kind() != reflect.String || !strings.HasPrefix(fv.String(), secretPrefix) {
+			return nil
+		}
+		plain, err := cm.decryptSecret(fv.String())
+		if err != nil {
+			return fmt.Errorf("decrypt field %q: %w", ft.key, err)
+		}
+		fv.SetString(plain)
+		return nil
+	})
+}
+
+// cloneStructPtr returns a shallow copy of v (a pointer to a struct) as a
+// new pointer, so secret fields can be encrypted for marshaling without
+// mutating the caller's value. ok is false if v is not a pointer to a
+// struct.
+func cloneStructPtr(v any) (clone any, ok bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	c := reflect.New(rv.Elem().Type())
+	c.Elem().Set(rv.Elem())
+	return c.Interface(), true
+}
+
+func structElem(v any) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	return rv.Elem(), true
+}
+
+// hasSecretFields reports whether v (a struct value or a pointer to one)
+// has any `gogacon:"...,secret"`-tagged field, even when v isn't a shape
+// cloneStructPtr/structElem can encrypt in place. SaveFrom uses this to
+// refuse such a v rather than silently marshaling its secrets as
+// plaintext.
+func hasSecretFields(v any) bool {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return false
+	}
+	found := false
+	walkTaggedFields(rv, func(ft fieldTag, fv reflect.Value) error {
+		if ft.secret {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
@@ -0,0 +1,273 @@
+package gogacon_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	keyring "github.com/zalando/go-keyring"
+
+	"github.com/Galdoba/gogacon"
+)
+
+type dbConfig struct {
+	Host     string `json:"host"`
+	Password string `json:"password" gogacon:"db.password,secret"`
+}
+
+func testAESKey() string {
+	return base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+}
+
+func TestSaveFrom_EncryptsSecretFields(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+
+	t.Setenv("TESTAPP_KEY", testAESKey())
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`{"host":"","password":""}`)},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+	manager.WithKeyProvider(gogacon.EnvKeyProvider{Var: "TESTAPP_KEY"})
+
+	var cfg dbConfig
+	if err := manager.LoadInto(path, &cfg); err != nil {
+		t.Fatalf("LoadInto failed: %v", err)
+	}
+
+	cfg.Host = "db.internal"
+	cfg.Password = "hunter2"
+	if err := manager.SaveFrom(&cfg); err != nil {
+		t.Fatalf("SaveFrom failed: %v", err)
+	}
+
+	// the in-memory value must stay plaintext
+	if cfg.Password != "hunter2" {
+		t.Errorf("SaveFrom must not mutate the caller's struct, got password %q", cfg.Password)
+	}
+
+	onDisk, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if strings.Contains(string(onDisk), "hunter2") {
+		t.Errorf("password must not appear in plaintext on disk: %s", onDisk)
+	}
+	if !strings.Contains(string(onDisk), "enc:v1:") {
+		t.Errorf("expected encrypted secret marker in saved file: %s", onDisk)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected 0600 perms on a file with secret fields, got %v", info.Mode().Perm())
+	}
+
+	// LoadInto should transparently decrypt on the way back in
+	var roundTripped dbConfig
+	if err := manager.LoadInto(path, &roundTripped); err != nil {
+		t.Fatalf("LoadInto (round trip) failed: %v", err)
+	}
+	if roundTripped.Password != "hunter2" {
+		t.Errorf("expected decrypted password, got %q", roundTripped.Password)
+	}
+	if roundTripped.Host != "db.internal" {
+		t.Errorf("expected host %q, got %q", "db.internal", roundTripped.Host)
+	}
+}
+
+func TestSaveFrom_TightensPermsOnExistingFile(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+	// Pre-create the file at the looser, non-atomic default mode, as if
+	// it had been saved once before any secret field existed.
+	if err := os.WriteFile(path, []byte(`{"host":"","password":""}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	t.Setenv("TESTAPP_KEY", testAESKey())
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`{"host":"","password":""}`)},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+	manager.WithKeyProvider(gogacon.EnvKeyProvider{Var: "TESTAPP_KEY"})
+
+	var cfg dbConfig
+	if err := manager.LoadInto(path, &cfg); err != nil {
+		t.Fatalf("LoadInto failed: %v", err)
+	}
+
+	cfg.Password = "hunter2"
+	if err := manager.SaveFrom(&cfg); err != nil {
+		t.Fatalf("SaveFrom failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected SaveFrom to tighten perms on an already-existing file to 0600, got %v", info.Mode().Perm())
+	}
+}
+
+func TestSaveFrom_KeyringKeyProvider(t *testing.T) {
+	keyring.MockInit()
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+
+	if err := keyring.Set("testapp", "config-key", testAESKey()); err != nil {
+		t.Fatalf("keyring.Set failed: %v", err)
+	}
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`{"host":"","password":""}`)},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+	manager.WithKeyProvider(gogacon.KeyringKeyProvider{Service: "testapp", User: "config-key"})
+
+	var cfg dbConfig
+	if err := manager.LoadInto(path, &cfg); err != nil {
+		t.Fatalf("LoadInto failed: %v", err)
+	}
+
+	cfg.Password = "hunter2"
+	if err := manager.SaveFrom(&cfg); err != nil {
+		t.Fatalf("SaveFrom failed: %v", err)
+	}
+
+	var roundTripped dbConfig
+	if err := manager.LoadInto(path, &roundTripped); err != nil {
+		t.Fatalf("LoadInto (round trip) failed: %v", err)
+	}
+	if roundTripped.Password != "hunter2" {
+		t.Errorf("expected password decrypted via keyring-sourced key, got %q", roundTripped.Password)
+	}
+}
+
+type nestedDBConfig struct {
+	DB struct {
+		Host     string `json:"host"`
+		Password string `json:"password" gogacon:"db.password,secret"`
+	} `json:"db"`
+}
+
+func TestSaveFrom_EncryptsNestedSecretFields(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+
+	t.Setenv("TESTAPP_KEY", testAESKey())
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`{"db":{"host":"","password":""}}`)},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+	manager.WithKeyProvider(gogacon.EnvKeyProvider{Var: "TESTAPP_KEY"})
+
+	var cfg nestedDBConfig
+	if err := manager.LoadInto(path, &cfg); err != nil {
+		t.Fatalf("LoadInto failed: %v", err)
+	}
+
+	cfg.DB.Password = "hunter2"
+	if err := manager.SaveFrom(&cfg); err != nil {
+		t.Fatalf("SaveFrom failed: %v", err)
+	}
+
+	onDisk, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if strings.Contains(string(onDisk), "hunter2") {
+		t.Errorf("nested password must not appear in plaintext on disk: %s", onDisk)
+	}
+
+	var roundTripped nestedDBConfig
+	if err := manager.LoadInto(path, &roundTripped); err != nil {
+		t.Fatalf("LoadInto (round trip) failed: %v", err)
+	}
+	if roundTripped.DB.Password != "hunter2" {
+		t.Errorf("expected decrypted nested password, got %q", roundTripped.DB.Password)
+	}
+}
+
+func TestSaveFrom_RejectsNonPointerWithSecretFields(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`{"host":"","password":""}`)},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	var cfg dbConfig
+	if err := manager.LoadInto(path, &cfg); err != nil {
+		t.Fatalf("LoadInto failed: %v", err)
+	}
+
+	cfg.Password = "hunter2"
+	err = manager.SaveFrom(cfg) // struct value, not a pointer: can't be encrypted in place
+	if err == nil {
+		t.Fatal("expected SaveFrom to reject a non-pointer value with secret fields")
+	}
+	if _, ok := err.(gogacon.ConfigError); !ok {
+		t.Errorf("expected ConfigError, got %T", err)
+	}
+
+	onDisk, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if strings.Contains(string(onDisk), "hunter2") {
+		t.Errorf("password must not appear in plaintext on disk: %s", onDisk)
+	}
+}
+
+func TestSaveFrom_NoKeyProviderFailsOnSecretField(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte(`{"host":"","password":""}`)},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	var cfg dbConfig
+	if err := manager.LoadInto(path, &cfg); err != nil {
+		t.Fatalf("LoadInto failed: %v", err)
+	}
+
+	cfg.Password = "hunter2"
+	err = manager.SaveFrom(&cfg)
+	if err == nil {
+		t.Fatal("expected SaveFrom to fail without a KeyProvider")
+	}
+	if _, ok := err.(gogacon.ConfigError); !ok {
+		t.Errorf("expected ConfigError, got %T", err)
+	}
+}
@@ -0,0 +1,122 @@
+package gogacon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long Watch waits after the last filesystem event
+// before reloading, so a burst of writes from an editor only triggers one
+// reload.
+const watchDebounce = 200 * time.Millisecond
+
+// Validator is an optional hook a Serializer can implement so Watch can
+// reject a reload before it replaces the current config.
+type Validator interface {
+	//Validate returns an error if the value is not acceptable as a reload
+	Validate() error
+}
+
+// Watch watches the file ConfigManager was loaded from for changes,
+// debounces rapid edits, and on settle re-reads the file and unmarshals it
+// into a fresh copy of target, invoking onChange with the previous and new
+// snapshots. The file's directory is watched rather than the file itself,
+// and events for other files in it are ignored, so editors that save via
+// rename (replacing the file with a new inode) are still picked up. If the
+// new value implements Validator and fails validation, the reload is
+// rejected and the previous config is kept. Watch blocks until ctx is
+// done, the underlying watcher fails, or a fresh copy of target cannot be
+// allocated for a reload.
+func (cm *ConfigManager) Watch(ctx context.Context, target Serializer, onChange func(old, new Serializer)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return NewError("start config watch", cm.filePath, err)
+	}
+	defer watcher.Close()
+
+	dir := filepath.Dir(cm.filePath)
+	if err := watcher.Add(dir); err != nil {
+		return NewError("start config watch", dir, err)
+	}
+
+	current := target
+	pending := make(chan struct{}, 1)
+	var timer *time.Timer
+	scheduleReload := func() {
+		if timer == nil {
+			timer = time.AfterFunc(watchDebounce, func() {
+				select {
+				case pending <- struct{}{}:
+				default:
+				}
+			})
+			return
+		}
+		timer.Reset(watchDebounce)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return NewError("watch config", cm.filePath, err)
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(ev.Name) != filepath.Clean(cm.filePath) {
+				continue
+			}
+			if ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			scheduleReload()
+		case <-pending:
+			next, err := freshCopy(current)
+			if err != nil {
+				return NewError("watch config", cm.filePath, err)
+			}
+			bt, err := os.ReadFile(cm.filePath)
+			if err != nil {
+				// The file may be mid-rewrite; wait for the next event.
+				continue
+			}
+			if err := next.Unmarshal(bt); err != nil {
+				continue
+			}
+			if v, ok := next.(Validator); ok {
+				if err := v.Validate(); err != nil {
+					continue
+				}
+			}
+			onChange(current, next)
+			current = next
+		}
+	}
+}
+
+// freshCopy allocates a new zero-value instance of v's concrete (pointer)
+// type, so each reload unmarshals into state independent of the previous
+// snapshot.
+func freshCopy(v Serializer) (Serializer, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("watch target must be a pointer, got %T", v)
+	}
+	next := reflect.New(rv.Elem().Type()).Interface()
+	s, ok := next.(Serializer)
+	if !ok {
+		return nil, fmt.Errorf("%T does not implement Serializer", next)
+	}
+	return s, nil
+}
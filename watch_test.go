@@ -0,0 +1,106 @@
+package gogacon_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Galdoba/gogacon"
+)
+
+// WatchableSerializer also implements gogacon.Validator, so the
+// reload-rejection path can be exercised: content equal to "invalid"
+// fails validation.
+type WatchableSerializer struct {
+	Data []byte
+}
+
+func (w *WatchableSerializer) Marshal() ([]byte, error) { return w.Data, nil }
+
+func (w *WatchableSerializer) Unmarshal(data []byte) error {
+	w.Data = data
+	return nil
+}
+
+func (w *WatchableSerializer) Validate() error {
+	if string(w.Data) == "invalid" {
+		return os.ErrInvalid
+	}
+	return nil
+}
+
+func TestWatch_ReloadsOnChange(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "default.conf")
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte("v1")},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	target := &MockSerializer{}
+	if err := manager.LoadConfig(path, target); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changes := make(chan gogacon.Serializer, 1)
+	go manager.Watch(ctx, target, func(old, new gogacon.Serializer) {
+		changes <- new
+	})
+
+	// Give the watcher time to start before writing.
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("v2"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	select {
+	case <-changes:
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for Watch to report a reload")
+	}
+}
+
+func TestWatch_RejectsInvalidReload(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "default.conf")
+
+	manager, err := gogacon.NewConfigManager(gogacon.Defaults{
+		AppName:             "testapp",
+		DefaultConfigValues: &MockSerializer{MarshalData: []byte("v1")},
+	})
+	if err != nil {
+		t.Fatalf("NewConfigManager failed: %v", err)
+	}
+
+	target := &WatchableSerializer{}
+	if err := manager.LoadConfig(path, target); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	var called bool
+	go manager.Watch(ctx, target, func(old, new gogacon.Serializer) {
+		called = true
+	})
+
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("invalid"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	<-ctx.Done()
+	if called {
+		t.Error("onChange should not fire when Validate rejects the reload")
+	}
+}